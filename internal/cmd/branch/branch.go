@@ -0,0 +1,29 @@
+package branch
+
+import (
+	"github.com/planetscale/cli/internal/cmd/shell"
+	"github.com/planetscale/cli/internal/cmdutil"
+
+	"github.com/spf13/cobra"
+)
+
+// BranchCmd is the parent command for all "pscale branch" subcommands.
+func BranchCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "branch <command>",
+		Short:   "Create, manage, and inspect database branches",
+		Aliases: []string{"b"},
+	}
+
+	cmd.AddCommand(CreateCmd(ch))
+	cmd.AddCommand(GetCmd(ch))
+	cmd.AddCommand(ListCmd(ch))
+	cmd.AddCommand(StatusCmd(ch))
+	cmd.AddCommand(DeleteCmd(ch))
+	cmd.AddCommand(DumpCmd(ch))
+	cmd.AddCommand(RestoreCmd(ch))
+	cmd.AddCommand(DiffCmd(ch))
+	cmd.AddCommand(shell.ExecCmd(ch))
+
+	return cmd
+}
@@ -0,0 +1,101 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	var flags struct {
+		notes  string
+		parent string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create <source-database> <branch>",
+		Short: "Create a branch of a database",
+		Args:  cmdutil.RequiredArgs("source-database", "branch"),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			source := args[0]
+			branch := args[1]
+
+			if source == branch {
+				return fmt.Errorf("a branch named %s already exists", printer.BoldBlue(branch))
+			}
+
+			web, err := cmd.Flags().GetBool("web")
+			if err != nil {
+				return err
+			}
+
+			if web {
+				ch.Printer.Println("🌐  Redirecting you to branch a database in your web browser.")
+				err := browser.OpenURL(fmt.Sprintf("%s/%s/%s/branches?name=%s&notes=%s&showDialog=true",
+					cmdutil.ApplicationURL, ch.Config.Organization, source, url.QueryEscape(branch), url.QueryEscape(flags.notes)))
+				if err != nil {
+					return err
+				}
+				return nil
+			}
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Creating branch %s for %s", printer.BoldBlue(branch), printer.BoldBlue(source)))
+			defer end()
+
+			b, err := client.DatabaseBranches.Create(ctx, &planetscale.CreateDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     source,
+				Branch:       branch,
+				Notes:        flags.notes,
+				ParentBranch: flags.parent,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("database %s does not exist in organization %s", printer.BoldBlue(source), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Database branch %s was successfully created.\n", printer.BoldBlue(b.Name))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(printer.NewDatabaseBranchPrinter(b))
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.Flags().StringVar(&flags.notes, "notes", "", "Notes for the database branch")
+	cmd.Flags().StringVar(&flags.parent, "parent", "", "Parent branch to branch off of")
+	cmd.Flags().BoolP("web", "w", false, "Create a branch in your web browser")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
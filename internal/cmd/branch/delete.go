@@ -0,0 +1,71 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "delete <source-database> <branch>",
+		Short: "Delete a branch of a database",
+		Args:  cmdutil.RequiredArgs("source-database", "branch"),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			source := args[0]
+			branch := args[1]
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Deleting branch %s from %s", printer.BoldBlue(branch), printer.BoldBlue(source)))
+			defer end()
+
+			err = client.DatabaseBranches.Delete(ctx, &planetscale.DeleteDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     source,
+				Branch:       branch,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("branch %s does not exist in database %s (organization: %s)",
+						printer.BoldBlue(branch), printer.BoldBlue(source), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Branch %s was successfully deleted from %s.\n", printer.BoldBlue(branch), printer.BoldBlue(source))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(map[string]string{"database": source, "branch": branch, "result": "deleted"})
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
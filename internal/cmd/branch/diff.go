@@ -0,0 +1,361 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+)
+
+// schemaDiff describes the tables that differ between two branches.
+type schemaDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+func (d schemaDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffCmd is the command for comparing the schema of two database
+// branches.
+func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		sql bool
+	}
+
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "diff <database> <base-branch> <compare-branch>",
+		Short: "Show the schema differences between two branches",
+		Args:  cmdutil.RequiredArgs("database", "base-branch", "compare-branch"),
+		Example: `The diff subcommand compares the schema of two branches of the same
+database and shows which tables were added, removed, or changed:
+
+  pscale branch diff mydatabase main mybranch
+
+Pass --sql to print a raw SQL migration script that can be piped straight
+into "mysql" instead of the human-readable summary: added tables are
+emitted as CREATE TABLE, removed tables as DROP TABLE, and changed tables
+as column-level ALTER TABLE statements, so existing rows in changed tables
+are kept. A column missing from the compare branch is dropped along with
+its data, and a table whose columns match but whose indexes or constraints
+differ gets a SQL comment instead of a statement.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			database := args[0]
+			base := args[1]
+			compare := args[2]
+
+			mysqldumpPath, err := exec.LookPath("mysqldump")
+			if err != nil {
+				return fmt.Errorf("could not find mysqldump in path: %s", err)
+			}
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Comparing %s against %s",
+				printer.BoldBlue(compare), printer.BoldBlue(base)))
+			defer end()
+
+			baseSchema, err := fetchSchema(ctx, ch, client, mysqldumpPath, database, base)
+			if err != nil {
+				return err
+			}
+
+			compareSchema, err := fetchSchema(ctx, ch, client, mysqldumpPath, database, compare)
+			if err != nil {
+				return err
+			}
+
+			end()
+
+			diff, script := diffSchemas(baseSchema, compareSchema)
+
+			if flags.sql {
+				ch.Printer.Println(script)
+				return nil
+			}
+
+			switch ch.Printer.Format() {
+			case printer.JSON, printer.YAML:
+				return ch.Printer.PrintResource(diff)
+			case printer.CSV:
+				return fmt.Errorf("branch diff does not support csv output; use table, json, or yaml")
+			}
+
+			printHumanDiff(ch, diff)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.Flags().BoolVar(&flags.sql, "sql", false, "Print a raw SQL migration script instead of a summary")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
+
+// fetchSchema returns a map of table name to its CREATE TABLE statement
+// for the given branch, by dumping the schema (no data) through the same
+// sql-proxy plumbing the other branch commands use.
+func fetchSchema(ctx context.Context, ch *cmdutil.Helper, client *ps.Client, mysqldumpPath, database, branch string) (map[string]string, error) {
+	host, port, loginFile, cleanup, err := dialBranch(ctx, ch, client, database, branch)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	args := []string{
+		fmt.Sprintf("--defaults-extra-file=%s", loginFile),
+		"-h", host,
+		"-P", port,
+		"--no-data",
+		"--skip-comments",
+		"--skip-add-drop-table",
+		database,
+	}
+
+	out, err := exec.CommandContext(ctx, mysqldumpPath, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCreateTables(string(out)), nil
+}
+
+// parseCreateTables splits a schema-only mysqldump output into a map of
+// table name to its CREATE TABLE statement.
+func parseCreateTables(dump string) map[string]string {
+	tables := map[string]string{}
+
+	statements := strings.Split(dump, ";\n")
+	for _, stmt := range statements {
+		stmt = strings.TrimSpace(stmt)
+		if !strings.HasPrefix(strings.ToUpper(stmt), "CREATE TABLE") {
+			continue
+		}
+
+		start := strings.Index(stmt, "`")
+		if start == -1 {
+			continue
+		}
+		end := strings.Index(stmt[start+1:], "`")
+		if end == -1 {
+			continue
+		}
+		name := stmt[start+1 : start+1+end]
+
+		tables[name] = stmt
+	}
+
+	return tables
+}
+
+func printHumanDiff(ch *cmdutil.Helper, diff schemaDiff) {
+	if diff.empty() {
+		ch.Printer.Println("No schema differences found.")
+		return
+	}
+
+	for _, t := range diff.Added {
+		ch.Printer.Println(fmt.Sprintf("+ %s", printer.BoldBlue(t)))
+	}
+	for _, t := range diff.Removed {
+		ch.Printer.Println(fmt.Sprintf("- %s", printer.BoldRed(t)))
+	}
+	for _, t := range diff.Changed {
+		ch.Printer.Println(fmt.Sprintf("~ %s", printer.Bold(t)))
+	}
+}
+
+// diffSchemas compares two sets of CREATE TABLE statements and returns a
+// summary plus a raw SQL script that would turn base into compare. Added
+// and removed tables are emitted as CREATE TABLE / DROP TABLE; changed
+// tables are emitted as column-level ALTER TABLE statements via
+// alterTableStatements, so existing rows in a changed table are never
+// dropped.
+func diffSchemas(base, compare map[string]string) (schemaDiff, string) {
+	var diff schemaDiff
+	var script []string
+
+	for table := range compare {
+		if _, ok := base[table]; !ok {
+			diff.Added = append(diff.Added, table)
+		}
+	}
+	for table := range base {
+		if _, ok := compare[table]; !ok {
+			diff.Removed = append(diff.Removed, table)
+		}
+	}
+	for table, baseDDL := range base {
+		if compareDDL, ok := compare[table]; ok && strings.TrimSpace(compareDDL) != strings.TrimSpace(baseDDL) {
+			diff.Changed = append(diff.Changed, table)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+
+	for _, table := range diff.Removed {
+		script = append(script, fmt.Sprintf("DROP TABLE `%s`;", table))
+	}
+	for _, table := range diff.Added {
+		script = append(script, strings.TrimSpace(compare[table])+";")
+	}
+	for _, table := range diff.Changed {
+		script = append(script, alterTableStatements(table, base[table], compare[table])...)
+	}
+
+	return diff, strings.Join(script, "\n")
+}
+
+// column is a single column name/definition pair parsed out of a CREATE
+// TABLE statement, in declaration order.
+type column struct {
+	name string
+	def  string
+}
+
+// alterTableStatements returns the ADD/MODIFY/DROP COLUMN statements that
+// turn baseDDL's columns into compareDDL's columns for table, so that
+// existing rows survive the migration. It only considers column
+// definitions: if two tables differ solely in their indexes or
+// constraints, that can't be expressed safely without also parsing and
+// diffing those clauses, so a comment is emitted instead of a statement.
+func alterTableStatements(table, baseDDL, compareDDL string) []string {
+	baseCols := parseColumns(baseDDL)
+	compareCols := parseColumns(compareDDL)
+
+	baseByName := make(map[string]string, len(baseCols))
+	for _, c := range baseCols {
+		baseByName[c.name] = c.def
+	}
+	compareByName := make(map[string]string, len(compareCols))
+	for _, c := range compareCols {
+		compareByName[c.name] = c.def
+	}
+
+	var stmts []string
+	for _, c := range compareCols {
+		if baseDef, ok := baseByName[c.name]; !ok {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s;", table, c.name, c.def))
+		} else if baseDef != c.def {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE `%s` MODIFY COLUMN `%s` %s;", table, c.name, c.def))
+		}
+	}
+	for _, c := range baseCols {
+		if _, ok := compareByName[c.name]; !ok {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`;", table, c.name))
+		}
+	}
+
+	if len(stmts) == 0 {
+		return []string{fmt.Sprintf("-- `%s` differs outside of its columns (indexes/constraints); write this ALTER TABLE by hand.", table)}
+	}
+
+	return stmts
+}
+
+// parseColumns extracts the column name/definition pairs from a CREATE
+// TABLE statement's body, in declaration order. Index, key, and
+// constraint clauses don't start with a backtick-quoted identifier at the
+// top level and are skipped.
+func parseColumns(createTable string) []column {
+	open := strings.Index(createTable, "(")
+	if open == -1 {
+		return nil
+	}
+
+	closeParen := matchingParen(createTable, open)
+	if closeParen == -1 {
+		return nil
+	}
+
+	var columns []column
+	for _, line := range splitTopLevel(createTable[open+1 : closeParen]) {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "`") {
+			continue
+		}
+
+		rest := line[1:]
+		end := strings.Index(rest, "`")
+		if end == -1 {
+			continue
+		}
+
+		columns = append(columns, column{
+			name: rest[:end],
+			def:  strings.TrimSpace(rest[end+1:]),
+		})
+	}
+
+	return columns
+}
+
+// matchingParen returns the index of the ")" that closes the "(" at open,
+// tracking paren depth rather than just looking for the last ")" in s, so a
+// trailing COMMENT/ENGINE/PARTITION BY clause containing its own parens
+// doesn't get mistaken for the column list's close.
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on commas that are not nested inside parentheses,
+// so that e.g. an enum('a,b') column definition isn't split mid-value.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+
+	return parts
+}
@@ -0,0 +1,132 @@
+package branch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCreateTables(t *testing.T) {
+	dump := "CREATE TABLE `users` (\n  `id` int\n);\nCREATE TABLE `posts` (\n  `id` int\n);\n"
+
+	tables := parseCreateTables(dump)
+
+	if len(tables) != 2 {
+		t.Fatalf("got %d tables, want 2: %v", len(tables), tables)
+	}
+	if _, ok := tables["users"]; !ok {
+		t.Errorf("missing users table")
+	}
+	if _, ok := tables["posts"]; !ok {
+		t.Errorf("missing posts table")
+	}
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "simple columns",
+			in:   "`id` int,\n  `name` varchar(255)",
+			want: []string{"`id` int", "\n  `name` varchar(255)"},
+		},
+		{
+			name: "comma nested in enum is not split",
+			in:   "`status` enum('a,b','c')",
+			want: []string{"`status` enum('a,b','c')"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitTopLevel(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitTopLevel(%q) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		createTable string
+		want        []column
+	}{
+		{
+			name:        "basic columns",
+			createTable: "CREATE TABLE `users` (\n  `id` int,\n  `name` varchar(255)\n)",
+			want: []column{
+				{name: "id", def: "int"},
+				{name: "name", def: "varchar(255)"},
+			},
+		},
+		{
+			name:        "trailing COMMENT containing a close paren isn't mistaken for the column list's close",
+			createTable: "CREATE TABLE `users` (\n  `id` int\n) ENGINE=InnoDB COMMENT='note (see docs)'",
+			want: []column{
+				{name: "id", def: "int"},
+			},
+		},
+		{
+			name:        "trailing PARTITION BY clause with nested parens",
+			createTable: "CREATE TABLE `users` (\n  `id` int\n) /*!50100 PARTITION BY HASH(id) PARTITIONS 4 */",
+			want: []column{
+				{name: "id", def: "int"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseColumns(tt.createTable)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns(%q) = %#v, want %#v", tt.createTable, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	base := map[string]string{
+		"users":   "CREATE TABLE `users` (\n  `id` int,\n  `name` varchar(255)\n)",
+		"removed": "CREATE TABLE `removed` (\n  `id` int\n)",
+	}
+	compare := map[string]string{
+		"users": "CREATE TABLE `users` (\n  `id` int,\n  `name` varchar(255),\n  `email` varchar(255)\n)",
+		"added": "CREATE TABLE `added` (\n  `id` int\n)",
+	}
+
+	diff, script := diffSchemas(base, compare)
+
+	if !reflect.DeepEqual(diff.Added, []string{"added"}) {
+		t.Errorf("Added = %v, want [added]", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []string{"removed"}) {
+		t.Errorf("Removed = %v, want [removed]", diff.Removed)
+	}
+	if !reflect.DeepEqual(diff.Changed, []string{"users"}) {
+		t.Errorf("Changed = %v, want [users]", diff.Changed)
+	}
+	if script == "" {
+		t.Errorf("expected a non-empty migration script")
+	}
+}
+
+func TestAlterTableStatements(t *testing.T) {
+	baseDDL := "CREATE TABLE `users` (\n  `id` int,\n  `name` varchar(255)\n)"
+	compareDDL := "CREATE TABLE `users` (\n  `id` bigint,\n  `email` varchar(255)\n)"
+
+	stmts := alterTableStatements("users", baseDDL, compareDDL)
+
+	want := []string{
+		"ALTER TABLE `users` MODIFY COLUMN `id` bigint;",
+		"ALTER TABLE `users` ADD COLUMN `email` varchar(255);",
+		"ALTER TABLE `users` DROP COLUMN `name`;",
+	}
+	if !reflect.DeepEqual(stmts, want) {
+		t.Errorf("alterTableStatements() = %#v, want %#v", stmts, want)
+	}
+}
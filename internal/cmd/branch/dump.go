@@ -0,0 +1,402 @@
+package branch
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/cli/internal/proxyutil"
+
+	"github.com/planetscale/sql-proxy/proxy"
+	"github.com/planetscale/sql-proxy/sigutil"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+)
+
+// DumpCmd is the command for dumping the schema and/or data of a database
+// branch to local SQL files.
+func DumpCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		tables     []string
+		schemaOnly bool
+		where      string
+		outDir     string
+		compress   bool
+		parallel   int
+	}
+
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "dump <database> <branch>",
+		Short: "Dump the schema and data of a database branch to local SQL files",
+		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Example: `The dump subcommand creates a logical backup of a database branch,
+writing one SQL file per table under a directory structure of
+dumps/<organization>/<database>/<branch>:
+
+  pscale branch dump mydatabase mybranch
+
+Use --schema-only to skip table data, --tables to limit the dump to
+specific tables, and --compress to write a single gzip'd tarball instead
+of individual .sql files.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			database := args[0]
+			branch := args[1]
+
+			mysqldumpPath, err := exec.LookPath("mysqldump")
+			if err != nil {
+				return fmt.Errorf("could not find mysqldump in path: %s", err)
+			}
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			host, port, tmpFile, cleanup, err := dialBranch(ctx, ch, client, database, branch)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			tables := flags.tables
+			if len(tables) == 0 {
+				if flags.where != "" {
+					return fmt.Errorf("--where requires --tables, since the WHERE clause may reference columns that don't exist on every table")
+				}
+				tables, err = fetchTableNames(ctx, host, port, tmpFile, database)
+				if err != nil {
+					return fmt.Errorf("couldn't list tables: %s", err)
+				}
+			}
+
+			outDir := flags.outDir
+			if outDir == "" {
+				outDir = filepath.Join("dumps", ch.Config.Organization, database, branch)
+			}
+			if err := os.MkdirAll(outDir, 0771); err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Dumping %s/%s to %s",
+				printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(outDir)))
+			defer end()
+
+			if err := dumpTables(ctx, dumpTablesOptions{
+				mysqldumpPath: mysqldumpPath,
+				host:          host,
+				port:          port,
+				loginFile:     tmpFile,
+				database:      database,
+				tables:        tables,
+				outDir:        outDir,
+				schemaOnly:    flags.schemaOnly,
+				where:         flags.where,
+				parallel:      flags.parallel,
+			}); err != nil {
+				return err
+			}
+
+			end()
+
+			if flags.compress {
+				archive := outDir + ".tar.gz"
+				if err := compressDir(outDir, archive); err != nil {
+					return err
+				}
+				if err := os.RemoveAll(outDir); err != nil {
+					return err
+				}
+				if ch.Printer.Format() == printer.Human {
+					ch.Printer.Printf("Wrote compressed dump to %s\n", printer.BoldBlue(archive))
+					return nil
+				}
+				outDir = archive
+			} else if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Wrote dump to %s\n", printer.BoldBlue(outDir))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(map[string]string{"database": database, "branch": branch, "path": outDir})
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.Flags().StringSliceVar(&flags.tables, "tables", nil, "Only dump the given tables (defaults to every table in the branch)")
+	cmd.Flags().BoolVar(&flags.schemaOnly, "schema-only", false, "Only dump the schema, skipping table data")
+	cmd.Flags().StringVar(&flags.where, "where", "", "WHERE clause used to filter the rows included in the dump; requires --tables")
+	cmd.Flags().StringVar(&flags.outDir, "output-dir", "", "Directory to write the dump to (defaults to dumps/<org>/<database>/<branch>)")
+	cmd.Flags().BoolVar(&flags.compress, "compress", false, "Write a single gzip'd tarball instead of individual .sql files")
+	cmd.Flags().IntVar(&flags.parallel, "parallel", 4, "Number of tables to dump concurrently")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
+
+// dialBranch checks that database/branch exist and are ready, starts a
+// sql-proxy instance for it the same way shell.ShellCmd does, and returns
+// the local host/port to connect to along with a mysql login file. The
+// returned cleanup func removes the login file and should always be
+// deferred.
+func dialBranch(ctx context.Context, ch *cmdutil.Helper, client *ps.Client, database, branch string) (host, port, loginFile string, cleanup func(), err error) {
+	noop := func() {}
+
+	_, err = client.DatabaseBranches.Get(ctx, &ps.GetDatabaseBranchRequest{
+		Organization: ch.Config.Organization,
+		Database:     database,
+		Branch:       branch,
+	})
+	if err != nil {
+		switch cmdutil.ErrCode(err) {
+		case ps.ErrNotFound:
+			return "", "", "", noop, fmt.Errorf("database %s and branch %s does not exist in organization %s",
+				printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(ch.Config.Organization))
+		default:
+			return "", "", "", noop, cmdutil.HandleError(err)
+		}
+	}
+
+	status, err := client.DatabaseBranches.GetStatus(ctx, &ps.GetDatabaseBranchStatusRequest{
+		Organization: ch.Config.Organization,
+		Database:     database,
+		Branch:       branch,
+	})
+	if err != nil {
+		return "", "", "", noop, cmdutil.HandleError(err)
+	}
+
+	if status.Credentials.User == "" {
+		return "", "", "", noop, errors.New("database branch is not ready yet")
+	}
+
+	proxyOpts := proxy.Options{
+		CertSource: proxyutil.NewRemoteCertSource(client),
+		LocalAddr:  "127.0.0.1:0",
+		Instance:   fmt.Sprintf("%s/%s/%s", ch.Config.Organization, database, branch),
+		Logger:     cmdutil.NewZapLogger(ch.Debug()),
+	}
+
+	p, err := proxy.NewClient(proxyOpts)
+	if err != nil {
+		return "", "", "", noop, fmt.Errorf("couldn't create proxy client: %s", err)
+	}
+
+	ctx = sigutil.WithSignal(ctx, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		err := p.Run(ctx)
+		if err != nil {
+			ch.Printer.Println("proxy error: ", err)
+		}
+	}()
+
+	addr, err := p.LocalAddr()
+	if err != nil {
+		return "", "", "", noop, err
+	}
+
+	host, port, err = net.SplitHostPort(addr.String())
+	if err != nil {
+		return "", "", "", noop, err
+	}
+
+	tmpFile, err := cmdutil.CreateLoginFile(status.Credentials.User, status.Credentials.Password)
+	cleanup = func() {
+		if tmpFile != "" {
+			os.Remove(tmpFile)
+		}
+	}
+	if err != nil {
+		return "", "", "", cleanup, err
+	}
+
+	return host, port, tmpFile, cleanup, nil
+}
+
+// fetchTableNames returns every table name in the given database by
+// shelling out to the mysql client.
+func fetchTableNames(ctx context.Context, host, port, loginFile, database string) ([]string, error) {
+	mysqlPath, err := cmdutil.MySQLClientPath()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		fmt.Sprintf("--defaults-extra-file=%s", loginFile),
+		"-h", host,
+		"-P", port,
+		"-N", "-B",
+		"-e", "SHOW TABLES",
+		database,
+	}
+
+	out, err := exec.CommandContext(ctx, mysqlPath, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			tables = append(tables, line)
+		}
+	}
+
+	return tables, nil
+}
+
+type dumpTablesOptions struct {
+	mysqldumpPath string
+	host, port    string
+	loginFile     string
+	database      string
+	tables        []string
+	outDir        string
+	schemaOnly    bool
+	where         string
+	parallel      int
+}
+
+// dumpTables dumps each table in opts.tables to its own .sql file under
+// opts.outDir, running up to opts.parallel dumps concurrently.
+func dumpTables(ctx context.Context, opts dumpTablesOptions) error {
+	parallel := opts.parallel
+	if parallel < 1 {
+		parallel = 4
+	}
+
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	errs := make(chan error, len(opts.tables))
+
+	for _, table := range opts.tables {
+		table := table
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := dumpTable(ctx, opts, table); err != nil {
+				errs <- fmt.Errorf("table %s: %s", table, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var dumpErrs []string
+	for err := range errs {
+		dumpErrs = append(dumpErrs, err.Error())
+	}
+	if len(dumpErrs) > 0 {
+		return fmt.Errorf("failed to dump %d table(s):\n%s", len(dumpErrs), strings.Join(dumpErrs, "\n"))
+	}
+
+	return nil
+}
+
+func dumpTable(ctx context.Context, opts dumpTablesOptions, table string) error {
+	args := []string{
+		fmt.Sprintf("--defaults-extra-file=%s", opts.loginFile),
+		"-h", opts.host,
+		"-P", opts.port,
+		"--skip-add-locks",
+		"--single-transaction",
+	}
+
+	if opts.schemaOnly {
+		args = append(args, "--no-data")
+	}
+
+	if opts.where != "" {
+		args = append(args, fmt.Sprintf("--where=%s", opts.where))
+	}
+
+	args = append(args, opts.database, table)
+
+	outPath := filepath.Join(opts.outDir, table+".sql")
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	c := exec.CommandContext(ctx, opts.mysqldumpPath, args...)
+	c.Stdout = out
+	c.Stderr = os.Stderr
+
+	return c.Run()
+}
+
+// compressDir writes every file under dir into a gzip'd tarball at dest.
+func compressDir(dir, dest string) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gw := gzip.NewWriter(out)
+	defer gw.Close()
+
+	tw := tar.NewWriter(gw)
+	defer tw.Close()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(filepath.Dir(dir), path)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, bufio.NewReader(f))
+		return err
+	})
+}
@@ -0,0 +1,102 @@
+package branch
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSqlFilesInDir(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "b.sql"), "select 1;")
+	writeFile(t, filepath.Join(dir, "a.sql"), "select 2;")
+	writeFile(t, filepath.Join(dir, "readme.txt"), "not sql")
+	if err := os.Mkdir(filepath.Join(dir, "subdir.sql"), 0755); err != nil {
+		t.Fatalf("Mkdir() = %v", err)
+	}
+
+	files, err := sqlFilesInDir(dir)
+	if err != nil {
+		t.Fatalf("sqlFilesInDir() = %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "a.sql"), filepath.Join(dir, "b.sql")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("sqlFilesInDir() = %v, want %v", files, want)
+	}
+}
+
+func TestCompressDir(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "mybranch")
+	if err := os.Mkdir(srcDir, 0755); err != nil {
+		t.Fatalf("Mkdir() = %v", err)
+	}
+
+	writeFile(t, filepath.Join(srcDir, "users.sql"), "create table users (id int);")
+	writeFile(t, filepath.Join(srcDir, "posts.sql"), "create table posts (id int);")
+
+	archive := filepath.Join(dir, "mybranch.tar.gz")
+	if err := compressDir(srcDir, archive); err != nil {
+		t.Fatalf("compressDir() = %v", err)
+	}
+
+	got := readTarGz(t, archive)
+
+	want := map[string]string{
+		filepath.Join("mybranch", "users.sql"): "create table users (id int);",
+		filepath.Join("mybranch", "posts.sql"): "create table posts (id int);",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("archive contents = %v, want %v", got, want)
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile(%s) = %v", path, err)
+	}
+}
+
+func readTarGz(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s) = %v", path, err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() = %v", err)
+	}
+	defer gr.Close()
+
+	contents := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar.Next() = %v", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("ReadAll() = %v", err)
+		}
+		contents[hdr.Name] = string(data)
+	}
+
+	return contents
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/planetscale/cli/internal/cmdutil"
-	"github.com/planetscale/cli/internal/config"
 	"github.com/planetscale/cli/internal/printer"
 
 	"github.com/planetscale/planetscale-go/planetscale"
@@ -14,11 +13,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func GetCmd(cfg *config.Config) *cobra.Command {
+func GetCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
 	cmd := &cobra.Command{
 		Use:   "get <source-database> <branch>",
 		Short: "Get a specific branch of a database",
 		Args:  cmdutil.RequiredArgs("source-database", "branch"),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			source := args[0]
@@ -30,43 +34,47 @@ func GetCmd(cfg *config.Config) *cobra.Command {
 			}
 
 			if web {
-				fmt.Println("🌐  Redirecting you to your database branch in your web browser.")
-				err := browser.OpenURL(fmt.Sprintf("%s/%s/%s/branches/%s", cmdutil.ApplicationURL, cfg.Organization, source, branch))
+				ch.Printer.Println("🌐  Redirecting you to your database branch in your web browser.")
+				err := browser.OpenURL(fmt.Sprintf("%s/%s/%s/branches/%s", cmdutil.ApplicationURL, ch.Config.Organization, source, branch))
 				if err != nil {
 					return err
 				}
 				return nil
 			}
 
-			client, err := cfg.NewClientFromConfig()
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
 			if err != nil {
 				return err
 			}
 
-			end := cmdutil.PrintProgress(fmt.Sprintf("Fetching branch %s for %s", cmdutil.BoldBlue(branch), cmdutil.BoldBlue(source)))
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Fetching branch %s for %s", printer.BoldBlue(branch), printer.BoldBlue(source)))
 			defer end()
 			b, err := client.DatabaseBranches.Get(ctx, &planetscale.GetDatabaseBranchRequest{
-				Organization: cfg.Organization,
+				Organization: ch.Config.Organization,
 				Database:     source,
 				Branch:       branch,
 			})
 			if err != nil {
-				if cmdutil.IsNotFoundError(err) {
-					return fmt.Errorf("%s does not exist in %s", cmdutil.BoldBlue(branch), cmdutil.BoldBlue(source))
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("%s does not exist in %s", printer.BoldBlue(branch), printer.BoldBlue(source))
+				default:
+					return cmdutil.HandleError(err)
 				}
-				return err
 			}
 
 			end()
-			err = printer.PrintOutput(cfg.OutputJSON, printer.NewDatabaseBranchPrinter(b))
-			if err != nil {
-				return err
-			}
 
-			return nil
+			return ch.Printer.PrintResource(printer.NewDatabaseBranchPrinter(b))
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
 	cmd.Flags().BoolP("web", "w", false, "Show a database branch in your web browser.")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
 	return cmd
 }
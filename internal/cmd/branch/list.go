@@ -0,0 +1,64 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:     "list <source-database>",
+		Short:   "List all branches of a database",
+		Args:    cmdutil.RequiredArgs("source-database"),
+		Aliases: []string{"ls"},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			source := args[0]
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Fetching branches for %s", printer.BoldBlue(source)))
+			defer end()
+
+			branches, err := client.DatabaseBranches.List(ctx, &planetscale.ListDatabaseBranchesRequest{
+				Organization: ch.Config.Organization,
+				Database:     source,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("database %s does not exist in organization %s", printer.BoldBlue(source), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			return ch.Printer.PrintResource(printer.NewDatabaseBranchSlicePrinter(branches))
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
@@ -0,0 +1,138 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+)
+
+// RestoreCmd is the command for loading a local dump, produced by
+// "pscale branch dump", into a database branch.
+func RestoreCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "restore <database> <branch> <dump-dir>",
+		Short: "Restore a local dump into a database branch",
+		Args:  cmdutil.RequiredArgs("database", "branch", "dump-dir"),
+		Example: `The restore subcommand loads a dump produced by "pscale branch dump"
+into a database branch:
+
+  pscale branch restore mydatabase mybranch dumps/myorg/mydatabase/mybranch
+
+Every .sql file found directly under the dump directory is loaded, in
+alphabetical order.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			database := args[0]
+			branch := args[1]
+			dumpDir := args[2]
+
+			files, err := sqlFilesInDir(dumpDir)
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				return fmt.Errorf("no .sql files found in %s", printer.BoldBlue(dumpDir))
+			}
+
+			mysqlPath, err := cmdutil.MySQLClientPath()
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			host, port, loginFile, cleanup, err := dialBranch(ctx, ch, client, database, branch)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Restoring %s into %s/%s",
+				printer.BoldBlue(dumpDir), printer.BoldBlue(database), printer.BoldBlue(branch)))
+			defer end()
+
+			for _, file := range files {
+				if err := restoreFile(ctx, mysqlPath, host, port, loginFile, database, file); err != nil {
+					return fmt.Errorf("couldn't restore %s: %s", filepath.Base(file), err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Restored %d file(s) into %s/%s\n", len(files), printer.BoldBlue(database), printer.BoldBlue(branch))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(map[string]interface{}{"database": database, "branch": branch, "files": len(files)})
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
+
+// sqlFilesInDir returns every .sql file directly under dir, sorted
+// alphabetically so files load in a predictable order.
+func sqlFilesInDir(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func restoreFile(ctx context.Context, mysqlPath, host, port, loginFile, database, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	args := []string{
+		fmt.Sprintf("--defaults-extra-file=%s", loginFile),
+		"-h", host,
+		"-P", port,
+		database,
+	}
+
+	c := exec.CommandContext(ctx, mysqlPath, args...)
+	c.Stdin = f
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	return c.Run()
+}
@@ -0,0 +1,66 @@
+package branch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+func StatusCmd(ch *cmdutil.Helper) *cobra.Command {
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "status <source-database> <branch>",
+		Short: "Show the status of a branch of a database",
+		Args:  cmdutil.RequiredArgs("source-database", "branch"),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			source := args[0]
+			branch := args[1]
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Fetching status of branch %s for %s", printer.BoldBlue(branch), printer.BoldBlue(source)))
+			defer end()
+
+			status, err := client.DatabaseBranches.GetStatus(ctx, &planetscale.GetDatabaseBranchStatusRequest{
+				Organization: ch.Config.Organization,
+				Database:     source,
+				Branch:       branch,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("branch %s does not exist in database %s (organization: %s)",
+						printer.BoldBlue(branch), printer.BoldBlue(source), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			return ch.Printer.PrintResource(printer.NewDatabaseBranchStatusPrinter(status))
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
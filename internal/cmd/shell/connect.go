@@ -0,0 +1,103 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/planetscale/sql-proxy/sigutil"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+type printableEndpoint struct {
+	Endpoint string `header:"endpoint" json:"endpoint"`
+	Mode     string `header:"mode" json:"mode"`
+}
+
+// ConnectCmd starts a local proxy for a database branch and prints the
+// endpoint to connect to, without exec'ing a MySQL client. It's meant for
+// IDEs, ORMs, and other long-running local apps that want a stable local
+// endpoint to point at.
+func ConnectCmd(ch *cmdutil.Helper) *cobra.Command {
+	lf := &listenerFlags{}
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "connect <database> <branch>",
+		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Short: "Start a local proxy to a database branch",
+		Example: `The connect subcommand starts a local proxy to a database branch and
+prints the endpoint to connect to, without opening an interactive shell:
+
+  pscale connect mydatabase mybranch
+
+By default it binds a random loopback TCP port. Pass --socket to bind a
+Unix domain socket instead, or --tls-cert/--tls-key to terminate TLS on
+the local TCP port, so IDEs and ORMs configured for TLS can connect
+through it directly.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			database := args[0]
+			branch := args[1]
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.DatabaseBranches.Get(ctx, &ps.GetDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Branch:       branch,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case ps.ErrNotFound:
+					return fmt.Errorf("database %s and branch %s does not exist in organization %s",
+						printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			ctx = sigutil.WithSignal(ctx, syscall.SIGINT, syscall.SIGTERM)
+
+			ln, cleanup, err := startLocalProxy(ctx, ch, client, database, branch, lf)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Proxy ready. Connect to %s/%s at:\n\n  %s\n\nPress ctrl-c to stop.\n",
+					printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(ln.Endpoint))
+			} else {
+				err := ch.Printer.PrintResource(&printableEndpoint{Endpoint: ln.Endpoint, Mode: string(ln.Mode)})
+				if err != nil {
+					return err
+				}
+			}
+
+			<-ctx.Done()
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	addListenerFlags(cmd, lf)
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
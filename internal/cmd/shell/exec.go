@@ -0,0 +1,264 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/cli/internal/proxyutil"
+
+	"github.com/planetscale/sql-proxy/sigutil"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+)
+
+// ExecCmd runs one-shot, non-interactive SQL against a database branch. It
+// reuses the same sql-proxy plumbing as ShellCmd, but streams the result
+// back through the standard printer instead of exec'ing an interactive
+// "mysql".
+func ExecCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		sql  string
+		file string
+	}
+
+	lf := &listenerFlags{}
+	var serviceToken *cmdutil.ServiceTokenFlags
+
+	cmd := &cobra.Command{
+		Use:   "exec [database] [branch]",
+		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Short: "Run a one-shot SQL statement against a database branch",
+		Example: `The exec subcommand runs a SQL statement against a database branch
+and prints the result, without opening an interactive shell:
+
+  pscale branch exec mydatabase mybranch --sql "select * from users limit 1"
+  pscale branch exec mydatabase mybranch -f migration.sql
+  cat migration.sql | pscale branch exec mydatabase mybranch
+
+Unlike "pscale shell", this works outside of a TTY, so it composes cleanly
+in scripts and CI. It exits with the same status code as the underlying
+"mysql" client.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			database := args[0]
+			branch := args[1]
+
+			mysqlPath, err := cmdutil.MySQLClientPath()
+			if err != nil {
+				return err
+			}
+
+			sqlFile, cleanupSQL, err := sqlInputFile(flags.sql, flags.file)
+			if err != nil {
+				return err
+			}
+			defer cleanupSQL()
+
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.DatabaseBranches.Get(ctx, &ps.GetDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Branch:       branch,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case ps.ErrNotFound:
+					return fmt.Errorf("database %s and branch %s does not exist in organization %s",
+						printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			status, err := client.DatabaseBranches.GetStatus(ctx, &ps.GetDatabaseBranchStatusRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Branch:       branch,
+			})
+			if err != nil {
+				return cmdutil.HandleError(err)
+			}
+
+			if status.Credentials.User == "" {
+				return errors.New("database branch is not ready yet")
+			}
+
+			ctx = sigutil.WithSignal(ctx, syscall.SIGINT, syscall.SIGTERM)
+
+			tmpFile, err := cmdutil.CreateLoginFile(status.Credentials.User, status.Credentials.Password)
+			if tmpFile != "" {
+				defer os.Remove(tmpFile)
+			}
+			if err != nil {
+				return err
+			}
+
+			ln, cleanupProxy, err := startLocalProxy(ctx, ch, client, database, branch, lf)
+			if err != nil {
+				return err
+			}
+			defer cleanupProxy()
+
+			mysqlArgs := []string{
+				fmt.Sprintf("--defaults-extra-file=%s", tmpFile),
+			}
+
+			if ln.Mode == proxyutil.Unix {
+				mysqlArgs = append(mysqlArgs, "--socket", ln.Addr)
+			} else {
+				host, port, err := net.SplitHostPort(ln.Addr)
+				if err != nil {
+					return err
+				}
+				mysqlArgs = append(mysqlArgs, "-h", host, "-P", port)
+			}
+
+			// table output wants mysql's aligned format; every other format
+			// is rendered by our own printer from tab-separated rows.
+			if ch.Printer.Format() == printer.Human {
+				mysqlArgs = append(mysqlArgs, "-t")
+			} else {
+				mysqlArgs = append(mysqlArgs, "--batch", "--raw")
+			}
+
+			mysqlArgs = append(mysqlArgs, database)
+
+			sqlIn, err := os.Open(sqlFile)
+			if err != nil {
+				return err
+			}
+			defer sqlIn.Close()
+
+			c := exec.CommandContext(ctx, mysqlPath, mysqlArgs...)
+			c.Stdin = sqlIn
+			c.Stderr = os.Stderr
+
+			if ch.Printer.Format() == printer.Human {
+				c.Stdout = os.Stdout
+				err = c.Run()
+			} else {
+				var out strings.Builder
+				c.Stdout = &out
+				err = c.Run()
+				if err == nil {
+					ch.Printer.PrintResource(parseTabularRows(out.String())) // nolint:errcheck
+				}
+			}
+
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				// os.Exit skips deferred cleanups, so run them explicitly
+				// before exiting with the mysql client's status code.
+				sqlIn.Close()
+				cleanupSQL()
+				cleanupProxy()
+				os.Remove(tmpFile)
+				os.Exit(exitErr.ExitCode())
+			}
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
+	cmd.Flags().StringVar(&flags.sql, "sql", "", "SQL statement to execute")
+	cmd.Flags().StringVarP(&flags.file, "file", "f", "", "Path to a .sql file to execute")
+	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
+
+	addListenerFlags(cmd, lf)
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
+
+// sqlInputFile resolves the SQL to execute into a file mysql can use as
+// stdin: the --sql flag if set, the --file flag if set, or otherwise
+// stdin itself buffered to a temp file. cleanup removes any temp file
+// created and should always be deferred.
+func sqlInputFile(sql, file string) (path string, cleanup func(), err error) {
+	noop := func() {}
+
+	if file != "" {
+		return file, noop, nil
+	}
+
+	if sql == "" {
+		data, err := readAllStdin()
+		if err != nil {
+			return "", noop, err
+		}
+		sql = data
+	}
+
+	tmp, err := ioutil.TempFile("", "pscale-exec-*.sql")
+	if err != nil {
+		return "", noop, err
+	}
+	if _, err := tmp.WriteString(sql); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", noop, err
+	}
+	tmp.Close()
+
+	return tmp.Name(), func() { os.Remove(tmp.Name()) }, nil
+}
+
+func readAllStdin() (string, error) {
+	var sb strings.Builder
+	buf := make([]byte, 4096)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n > 0 {
+			sb.Write(buf[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+// parseTabularRows parses mysql's --batch --raw tab-separated output
+// (header row followed by data rows) into a printer.Rows suitable for
+// PrintResource. Rows keeps columns in their declaration order, unlike a
+// map, so json/csv/table output stays stable across rows and runs.
+func parseTabularRows(out string) printer.Rows {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return printer.Rows{}
+	}
+
+	headers := strings.Split(lines[0], "\t")
+
+	rows := printer.Rows{Headers: headers}
+	for _, line := range lines[1:] {
+		cols := strings.Split(line, "\t")
+		row := make([]string, len(headers))
+		for i := range headers {
+			if i < len(cols) {
+				row[i] = cols[i]
+			}
+		}
+		rows.Rows = append(rows.Rows, row)
+	}
+
+	return rows
+}
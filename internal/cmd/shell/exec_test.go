@@ -0,0 +1,57 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetscale/cli/internal/printer"
+)
+
+func TestParseTabularRows(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want printer.Rows
+	}{
+		{
+			name: "empty output",
+			out:  "",
+			want: printer.Rows{},
+		},
+		{
+			name: "header only",
+			out:  "id\tname\n",
+			want: printer.Rows{Headers: []string{"id", "name"}},
+		},
+		{
+			name: "multiple rows keep column order",
+			out:  "id\tname\temail\n1\talice\talice@example.com\n2\tbob\tbob@example.com\n",
+			want: printer.Rows{
+				Headers: []string{"id", "name", "email"},
+				Rows: [][]string{
+					{"1", "alice", "alice@example.com"},
+					{"2", "bob", "bob@example.com"},
+				},
+			},
+		},
+		{
+			name: "short row is padded with empty columns",
+			out:  "id\tname\temail\n1\talice\n",
+			want: printer.Rows{
+				Headers: []string{"id", "name", "email"},
+				Rows: [][]string{
+					{"1", "alice", ""},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTabularRows(tt.out)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTabularRows(%q) = %#v, want %#v", tt.out, got, tt.want)
+			}
+		})
+	}
+}
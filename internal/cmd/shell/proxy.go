@@ -0,0 +1,123 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/proxyutil"
+
+	"github.com/planetscale/sql-proxy/proxy"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+// addListenerFlags registers the --local-addr/--remote-addr/--socket/
+// --tls-cert/--tls-key flags shared by ShellCmd, ExecCmd, and ConnectCmd.
+func addListenerFlags(cmd *cobra.Command, lf *listenerFlags) {
+	cmd.PersistentFlags().StringVar(&lf.localAddr, "local-addr",
+		"", "Local address to bind and listen for connections. By default the proxy binds to 127.0.0.1 with a random port.")
+	cmd.PersistentFlags().StringVar(&lf.remoteAddr, "remote-addr", "",
+		"PlanetScale Database remote network address. By default the remote address is populated automatically from the PlanetScale API.")
+	cmd.PersistentFlags().StringVar(&lf.socket, "socket", "",
+		"Bind a Unix domain socket at this path instead of a TCP port.")
+	cmd.PersistentFlags().StringVar(&lf.tlsCert, "tls-cert", "",
+		"Path to a TLS certificate. When set with --tls-key, the local proxy terminates TLS on its TCP port instead of plain loopback TCP.")
+	cmd.PersistentFlags().StringVar(&lf.tlsKey, "tls-key", "",
+		"Path to a TLS private key. Used together with --tls-cert.")
+}
+
+// listenerFlags holds the flags that select which proxyutil.LocalListener
+// mode a command should expose, shared by ShellCmd, ExecCmd, and ConnectCmd.
+type listenerFlags struct {
+	localAddr  string
+	remoteAddr string
+	socket     string
+	tlsCert    string
+	tlsKey     string
+}
+
+func (f *listenerFlags) listenerOptions() (proxyutil.ListenerOptions, error) {
+	localAddr := f.localAddr
+	if localAddr == "" {
+		localAddr = "127.0.0.1:0"
+	}
+
+	switch {
+	case f.socket != "" && (f.tlsCert != "" || f.tlsKey != ""):
+		return proxyutil.ListenerOptions{}, fmt.Errorf("--socket and --tls-cert/--tls-key are mutually exclusive")
+	case f.socket != "":
+		return proxyutil.ListenerOptions{Mode: proxyutil.Unix, SocketPath: f.socket}, nil
+	case f.tlsCert != "" || f.tlsKey != "":
+		return proxyutil.ListenerOptions{Mode: proxyutil.TLS, TCPAddr: localAddr, CertFile: f.tlsCert, KeyFile: f.tlsKey}, nil
+	default:
+		return proxyutil.ListenerOptions{Mode: proxyutil.TCP, TCPAddr: localAddr}, nil
+	}
+}
+
+// startLocalProxy starts a sql-proxy instance for the given database branch
+// and, based on lf, exposes it either directly (TCP, the historical
+// behavior) or bridged through a proxyutil.LocalListener bound to a Unix
+// socket or a TLS-terminated port. The returned LocalListener's Endpoint is
+// what clients should connect to; cleanup stops any bridging goroutine and
+// should always be deferred.
+func startLocalProxy(ctx context.Context, ch *cmdutil.Helper, client *ps.Client, database, branch string, lf *listenerFlags) (*proxyutil.LocalListener, func(), error) {
+	opts, err := lf.listenerOptions()
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	// sql-proxy only binds plain loopback TCP itself, so for TCP mode we
+	// let it bind the requested address directly. For Unix/TLS modes we
+	// still need a real TCP proxy underneath; we bind that on an ephemeral
+	// port and bridge the public listener to it.
+	internalAddr := opts.TCPAddr
+	if opts.Mode != proxyutil.TCP {
+		internalAddr = "127.0.0.1:0"
+	}
+
+	proxyOpts := proxy.Options{
+		CertSource: proxyutil.NewRemoteCertSource(client),
+		LocalAddr:  internalAddr,
+		RemoteAddr: lf.remoteAddr,
+		Instance:   fmt.Sprintf("%s/%s/%s", ch.Config.Organization, database, branch),
+		Logger:     cmdutil.NewZapLogger(ch.Debug()),
+	}
+
+	p, err := proxy.NewClient(proxyOpts)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("couldn't create proxy client: %s", err)
+	}
+
+	go func() {
+		err := p.Run(ctx)
+		if err != nil {
+			ch.Printer.Println("proxy error: ", err)
+		}
+	}()
+
+	addr, err := p.LocalAddr()
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	if opts.Mode == proxyutil.TCP {
+		return &proxyutil.LocalListener{Addr: addr.String(), Endpoint: addr.String(), Mode: proxyutil.TCP}, func() {}, nil
+	}
+
+	ln, err := proxyutil.Listen(opts)
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	bridgeCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := proxyutil.Bridge(bridgeCtx, ln, addr.String()); err != nil {
+			ch.Printer.Println("proxy error: ", err)
+		}
+	}()
+
+	return ln, cancel, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"os"
@@ -18,7 +17,6 @@ import (
 	"github.com/planetscale/cli/internal/promptutil"
 	"github.com/planetscale/cli/internal/proxyutil"
 
-	"github.com/planetscale/sql-proxy/proxy"
 	"github.com/planetscale/sql-proxy/sigutil"
 
 	ps "github.com/planetscale/planetscale-go/planetscale"
@@ -29,10 +27,9 @@ import (
 )
 
 func ShellCmd(ch *cmdutil.Helper) *cobra.Command {
-	var flags struct {
-		localAddr  string
-		remoteAddr string
-	}
+	lf := &listenerFlags{}
+
+	var serviceToken *cmdutil.ServiceTokenFlags
 
 	cmd := &cobra.Command{
 		Use: "shell [database] [branch]",
@@ -46,13 +43,19 @@ By default, if no branch names are given and there is only one branch, it
 automatically opens a shell to that branch:
 
   pscale shell mydatabase
- 
+
 If there are multiple branches for the given database, you'll be prompted to
 choose one. To open a shell instance to a specific branch, pass the branch as a
 second argument:
 
-  pscale shell mydatabase mybranch`,
-		PersistentPreRunE: cmdutil.CheckAuthentication(ch.Config),
+  pscale shell mydatabase mybranch
+
+To run non-interactively, e.g. from CI, pass a service token via
+--service-token / --service-token-id (or the PSCALE_SERVICE_TOKEN /
+PSCALE_SERVICE_TOKEN_ID environment variables) instead of logging in.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdutil.CheckAuthenticationOrServiceToken(ch.Config, serviceToken)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			database := args[0]
@@ -66,7 +69,7 @@ second argument:
 				return err
 			}
 
-			client, err := ch.Config.NewClientFromConfig()
+			client, err := cmdutil.ClientFromConfig(ch.Config, serviceToken)
 			if err != nil {
 				return err
 			}
@@ -99,36 +102,10 @@ second argument:
 				}
 			}
 
-			const localProxyAddr = "127.0.0.1"
-			localAddr := localProxyAddr + ":0"
-			if flags.localAddr != "" {
-				localAddr = flags.localAddr
-			}
-
-			proxyOpts := proxy.Options{
-				CertSource: proxyutil.NewRemoteCertSource(client),
-				LocalAddr:  localAddr,
-				RemoteAddr: flags.remoteAddr,
-				Instance:   fmt.Sprintf("%s/%s/%s", ch.Config.Organization, database, branch),
-				Logger:     cmdutil.NewZapLogger(ch.Debug()),
-			}
-
-			p, err := proxy.NewClient(proxyOpts)
-			if err != nil {
-				return fmt.Errorf("couldn't create proxy client: %s", err)
-			}
-
 			// TODO(fatih): replace with signal.NotifyContext once Go 1.16 is released
 			// https://go-review.googlesource.com/c/go/+/219640
 			ctx = sigutil.WithSignal(ctx, syscall.SIGINT, syscall.SIGTERM)
 
-			go func() {
-				err := p.Run(ctx)
-				if err != nil {
-					ch.Printer.Println("proxy error: ", err)
-				}
-			}()
-
 			status, err := client.DatabaseBranches.GetStatus(ctx, &ps.GetDatabaseBranchStatusRequest{
 				Organization: ch.Config.Organization,
 				Database:     database,
@@ -148,7 +125,7 @@ second argument:
 				return errors.New("database branch is not ready yet")
 			}
 
-			tmpFile, err := createLoginFile(status.Credentials.User, status.Credentials.Password)
+			tmpFile, err := cmdutil.CreateLoginFile(status.Credentials.User, status.Credentials.Password)
 			if tmpFile != "" {
 				defer os.Remove(tmpFile)
 			}
@@ -156,22 +133,26 @@ second argument:
 				return err
 			}
 
-			addr, err := p.LocalAddr()
-			if err != nil {
-				return err
-			}
-
-			host, port, err := net.SplitHostPort(addr.String())
+			ln, cleanup, err := startLocalProxy(ctx, ch, client, database, branch, lf)
 			if err != nil {
 				return err
 			}
+			defer cleanup()
 
 			mysqlArgs := []string{
 				fmt.Sprintf("--defaults-extra-file=%s", tmpFile),
 				"-s",
 				"-t", // the -s (silent) flag disables tabular output, re-enable it.
-				"-h", host,
-				"-P", port,
+			}
+
+			if ln.Mode == proxyutil.Unix {
+				mysqlArgs = append(mysqlArgs, "--socket", ln.Addr)
+			} else {
+				host, port, err := net.SplitHostPort(ln.Addr)
+				if err != nil {
+					return err
+				}
+				mysqlArgs = append(mysqlArgs, "-h", host, "-P", port)
 			}
 
 			historyFile, err := historyFilePath(ch.Config.Organization, database, branch)
@@ -196,12 +177,11 @@ second argument:
 	}
 
 	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
-	cmd.PersistentFlags().StringVar(&flags.localAddr, "local-addr",
-		"", "Local address to bind and listen for connections. By default the proxy binds to 127.0.0.1 with a random port.")
-	cmd.PersistentFlags().StringVar(&flags.remoteAddr, "remote-addr", "",
-		"PlanetScale Database remote network address. By default the remote address is populated automatically from the PlanetScale API.")
 	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
 
+	addListenerFlags(cmd, lf)
+	serviceToken = cmdutil.AddServiceTokenFlags(cmd)
+
 	return cmd
 }
 
@@ -236,22 +216,6 @@ func formatMySQLBranch(database, branch string) string {
 	return fmt.Sprintf("%s/%s> ", printer.Bold(database), branchStyled)
 }
 
-// createLoginFile creates a temporary file to store the username and password, so we don't have to
-// pass them as `mysql` command-line arguments.
-func createLoginFile(username, password string) (string, error) {
-	// ioutil.TempFile defaults to creating the file in the OS temporary directory with 0600 permissions
-	tmpFile, err := ioutil.TempFile("", "pscale-*")
-	if err != nil {
-		fmt.Println("could not create temporary file: ", err)
-		return "", err
-	}
-	fmt.Fprintln(tmpFile, "[client]")
-	fmt.Fprintf(tmpFile, "user=%s\n", username)
-	fmt.Fprintf(tmpFile, "password=%s\n", password)
-	_ = tmpFile.Close()
-	return tmpFile.Name(), nil
-}
-
 type mysql struct {
 	mysqlPath    string
 	dir          string
@@ -0,0 +1,77 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/planetscale-go/planetscale"
+	"github.com/spf13/cobra"
+)
+
+func AddAccessCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		accesses []string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "add-access <id> <database>",
+		Short: "grant a service token access to a database",
+		Args:  cmdutil.RequiredArgs("id", "database"),
+		Example: `The add-access subcommand scopes a service token to a specific
+database, with one or more access levels:
+
+  pscale service-token add-access $TOKEN_ID mydatabase --access read_branch,write_branch`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			id := args[0]
+			database := args[1]
+
+			if len(flags.accesses) == 0 {
+				return fmt.Errorf("at least one --access value is required")
+			}
+
+			client, err := ch.Client()
+			if err != nil {
+				return err
+			}
+
+			req := &planetscale.AddServiceTokenAccessRequest{
+				Organization: ch.Config.Organization,
+				ID:           id,
+				Database:     database,
+				Accesses:     flags.accesses,
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Granting service token %s access to %s", printer.BoldBlue(id), printer.BoldBlue(database)))
+			defer end()
+
+			_, err = client.ServiceTokens.AddAccess(ctx, req)
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("service token %s or database %s does not exist in organization %s",
+						printer.BoldBlue(id), printer.BoldBlue(database), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Service token %s was granted %s access to %s.\n",
+					printer.BoldBlue(id), printer.BoldBlue(fmt.Sprint(flags.accesses)), printer.BoldBlue(database))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(map[string]interface{}{"id": id, "database": database, "access": flags.accesses})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&flags.accesses, "access", nil, "Comma-separated access levels to grant, e.g. read_branch,write_branch")
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/planetscale-go/planetscale"
+	"github.com/spf13/cobra"
+)
+
+func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a service token for the organization",
+		Example: `The create subcommand creates a new service token for the
+organization. The token's secret is only ever printed once, so make sure
+to save it:
+
+  pscale service-token create`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := ch.Client()
+			if err != nil {
+				return err
+			}
+
+			req := &planetscale.CreateServiceTokenRequest{
+				Organization: ch.Config.Organization,
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Creating service token in org %s", printer.BoldBlue(ch.Config.Organization)))
+			defer end()
+
+			token, err := client.ServiceTokens.Create(ctx, req)
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("organization %s does not exist", printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Service token %s created.\n", printer.BoldBlue(token.ID))
+				ch.Printer.Println("Make sure to save its secret, it will not be shown again:")
+				ch.Printer.Println(printer.BoldBlue(token.Token))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(toServiceToken(token))
+		},
+	}
+
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
+
+// printableServiceToken is a subset of planetscale.ServiceToken used for
+// single-token output, e.g. from "token create".
+type printableServiceToken struct {
+	ID    string `header:"id" json:"id"`
+	Name  string `header:"name" json:"name"`
+	Token string `header:"token" json:"token"`
+}
+
+func toServiceToken(t *planetscale.ServiceToken) *printableServiceToken {
+	return &printableServiceToken{
+		ID:    t.ID,
+		Name:  t.Name,
+		Token: t.Token,
+	}
+}
@@ -0,0 +1,59 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/planetscale-go/planetscale"
+	"github.com/spf13/cobra"
+)
+
+func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "delete a service token for the organization",
+		Args:  cmdutil.RequiredArgs("id"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			id := args[0]
+
+			client, err := ch.Client()
+			if err != nil {
+				return err
+			}
+
+			req := &planetscale.DeleteServiceTokenRequest{
+				Organization: ch.Config.Organization,
+				ID:           id,
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Deleting service token %s from org %s", printer.BoldBlue(id), printer.BoldBlue(ch.Config.Organization)))
+			defer end()
+
+			err = client.ServiceTokens.Delete(ctx, req)
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("service token %s does not exist in organization %s", printer.BoldBlue(id), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			end()
+
+			if ch.Printer.Format() == printer.Human {
+				ch.Printer.Printf("Service token %s was successfully deleted.\n", printer.BoldBlue(id))
+				return nil
+			}
+
+			return ch.Printer.PrintResource(map[string]string{"id": id, "result": "deleted"})
+		},
+	}
+
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
+	return cmd
+}
@@ -44,5 +44,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmdutil.AddOutputFlag(cmd, ch.Printer)
+
 	return cmd
 }
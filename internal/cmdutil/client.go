@@ -0,0 +1,72 @@
+package cmdutil
+
+import (
+	"errors"
+	"os"
+
+	"github.com/planetscale/cli/internal/config"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+// ServiceTokenFlags holds the --service-token / --service-token-id flag
+// values used to authenticate non-interactively, e.g. from CI.
+type ServiceTokenFlags struct {
+	ID    string
+	Token string
+}
+
+// AddServiceTokenFlags registers --service-token and --service-token-id on
+// cmd, for commands that can run non-interactively against a service
+// token instead of an OAuth login.
+func AddServiceTokenFlags(cmd *cobra.Command) *ServiceTokenFlags {
+	flags := &ServiceTokenFlags{}
+	cmd.PersistentFlags().StringVar(&flags.ID, "service-token-id", "",
+		"The id of the service token used for authenticating, instead of an interactive login. Can also be set via the PSCALE_SERVICE_TOKEN_ID environment variable.")
+	cmd.PersistentFlags().StringVar(&flags.Token, "service-token", "",
+		"The service token used for authenticating, instead of an interactive login. Can also be set via the PSCALE_SERVICE_TOKEN environment variable.")
+	return flags
+}
+
+// Resolve fills in any unset fields from the PSCALE_SERVICE_TOKEN*
+// environment variables and reports whether a service token was provided.
+func (f *ServiceTokenFlags) Resolve() bool {
+	if f == nil {
+		return false
+	}
+	if f.ID == "" {
+		f.ID = os.Getenv("PSCALE_SERVICE_TOKEN_ID")
+	}
+	if f.Token == "" {
+		f.Token = os.Getenv("PSCALE_SERVICE_TOKEN")
+	}
+	return f.Token != ""
+}
+
+// ClientFromConfig returns a planetscale-go client authenticated with the
+// given service token, falling back to cfg.NewClientFromConfig() (the
+// interactive OAuth login) when no service token was provided.
+func ClientFromConfig(cfg *config.Config, svcToken *ServiceTokenFlags) (*ps.Client, error) {
+	if svcToken.Resolve() {
+		if svcToken.ID == "" {
+			return nil, errors.New("--service-token-id (or PSCALE_SERVICE_TOKEN_ID) is required when using --service-token")
+		}
+		return ps.NewClient(ps.WithServiceToken(svcToken.ID, svcToken.Token))
+	}
+
+	return cfg.NewClientFromConfig()
+}
+
+// CheckAuthenticationOrServiceToken behaves like CheckAuthentication, but
+// skips the interactive-login check when a service token was provided via
+// svcToken, so commands can run non-interactively in CI.
+func CheckAuthenticationOrServiceToken(cfg *config.Config, svcToken *ServiceTokenFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if svcToken.Resolve() {
+			return nil
+		}
+		return CheckAuthentication(cfg)(cmd, args)
+	}
+}
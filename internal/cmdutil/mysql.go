@@ -0,0 +1,22 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io/ioutil"
+)
+
+// CreateLoginFile creates a temporary file to store the username and
+// password, so we don't have to pass them as `mysql` command-line
+// arguments.
+func CreateLoginFile(username, password string) (string, error) {
+	// ioutil.TempFile defaults to creating the file in the OS temporary directory with 0600 permissions
+	tmpFile, err := ioutil.TempFile("", "pscale-*")
+	if err != nil {
+		return "", fmt.Errorf("could not create temporary file: %s", err)
+	}
+	fmt.Fprintln(tmpFile, "[client]")
+	fmt.Fprintf(tmpFile, "user=%s\n", username)
+	fmt.Fprintf(tmpFile, "password=%s\n", password)
+	_ = tmpFile.Close()
+	return tmpFile.Name(), nil
+}
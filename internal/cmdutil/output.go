@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/spf13/cobra"
+)
+
+// outputFormats maps the --output/-o flag values to a printer.Format.
+var outputFormats = map[string]printer.Format{
+	"table": printer.Human,
+	"json":  printer.JSON,
+	"yaml":  printer.YAML,
+	"csv":   printer.CSV,
+}
+
+// AddOutputFlag registers the --output/-o flag used by every branch and
+// token subcommand to select table, json, yaml, or csv output, and wires it
+// into p once flags are parsed. It wraps any PersistentPreRunE already set
+// on cmd, so it should be called after cmd is otherwise fully constructed.
+func AddOutputFlag(cmd *cobra.Command, p *printer.Printer) {
+	var output string
+	cmd.PersistentFlags().StringVarP(&output, "output", "o", "table",
+		"Show output in a specific format. Possible values: table, json, yaml, csv")
+
+	prev := cmd.PersistentPreRunE
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if prev != nil {
+			if err := prev(cmd, args); err != nil {
+				return err
+			}
+		}
+
+		format, ok := outputFormats[output]
+		if !ok {
+			return fmt.Errorf("invalid output format %q, possible values are: table, json, yaml, csv", output)
+		}
+
+		return p.SetFormat(format)
+	}
+}
@@ -0,0 +1,145 @@
+// Package printer renders command output in the format the user asked for
+// (human-readable text, json, yaml, or csv) behind a single Printer type, so
+// commands don't special-case output formats themselves.
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/briandowns/spinner"
+	isatty "github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v2"
+)
+
+// Format is an output format a Printer can render resources as.
+type Format string
+
+const (
+	Human Format = "human"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	CSV   Format = "csv"
+)
+
+// IsTTY reports whether stdout is attached to a terminal. Commands use it to
+// decide whether to show progress spinners and colored output.
+var IsTTY = isatty.IsTerminal(os.Stdout.Fd())
+
+// Printer renders resources in one of Format's formats to an underlying
+// writer. The zero value is not usable; use NewPrinter.
+type Printer struct {
+	format Format
+	out    io.Writer
+}
+
+// NewPrinter returns a Printer that writes to out in the default (human)
+// format. A nil out defaults to os.Stdout.
+func NewPrinter(out io.Writer) *Printer {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Printer{format: Human, out: out}
+}
+
+// Format returns the printer's current output format.
+func (p *Printer) Format() Format {
+	return p.format
+}
+
+// SetFormat sets the printer's output format, used by AddOutputFlag once the
+// --output flag has been parsed.
+func (p *Printer) SetFormat(f Format) error {
+	switch f {
+	case Human, JSON, YAML, CSV:
+		p.format = f
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s", f)
+	}
+}
+
+// PrintResource renders v in the printer's current format. For JSON and YAML
+// it marshals v directly, so v can be any shape. For table and csv it
+// renders v as rows via toRows, which requires v to be a Rows, a map, a
+// struct, or a slice of structs with `header` tags.
+func (p *Printer) PrintResource(v interface{}) error {
+	switch p.format {
+	case JSON:
+		return printJSON(p.out, v)
+	case YAML:
+		return printYAML(p.out, v)
+	case CSV:
+		return printTabular(p.out, v, true)
+	default:
+		return printTabular(p.out, v, false)
+	}
+}
+
+// PrintProgress prints label followed by a spinner when the format is human
+// and stdout is a TTY; otherwise it prints just label, as a line of its own.
+// It returns a func that stops the spinner and should always be deferred;
+// calling it more than once is a no-op.
+func (p *Printer) PrintProgress(label string) func() {
+	if p.format != Human || !IsTTY {
+		fmt.Fprintln(p.out, label)
+		return func() {}
+	}
+
+	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
+	s.Suffix = " " + label
+	s.Writer = p.out
+	s.Start()
+
+	var stopped bool
+	return func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		s.Stop()
+	}
+}
+
+// Println writes args to the printer, space-separated, followed by a
+// newline, regardless of output format.
+func (p *Printer) Println(args ...interface{}) {
+	fmt.Fprintln(p.out, args...)
+}
+
+// Printf writes a formatted string to the printer, regardless of output
+// format.
+func (p *Printer) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.out, format, args...)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func printYAML(w io.Writer, v interface{}) error {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// Bold wraps s in the terminal escape codes for bold text.
+func Bold(s string) string {
+	return fmt.Sprintf("\x1b[1m%s\x1b[0m", s)
+}
+
+// BoldBlue wraps s in the terminal escape codes for bold blue text.
+func BoldBlue(s string) string {
+	return fmt.Sprintf("\x1b[1;34m%s\x1b[0m", s)
+}
+
+// BoldRed wraps s in the terminal escape codes for bold red text.
+func BoldRed(s string) string {
+	return fmt.Sprintf("\x1b[1;31m%s\x1b[0m", s)
+}
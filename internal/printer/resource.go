@@ -0,0 +1,207 @@
+package printer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+
+	ps "github.com/planetscale/planetscale-go/planetscale"
+)
+
+// Rows is an explicit, declaration-ordered table: Headers[i] names the
+// column that Rows[n][i] belongs to. Callers that can't express their data
+// as a single struct or slice of structs (e.g. rows parsed out of another
+// tool's tabular output) build a Rows directly instead of relying on the
+// `header`-tag reflection toRows otherwise uses.
+type Rows struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// toRows converts v into a Rows for table/csv rendering. v may be a Rows, a
+// *Rows, a struct with `header`-tagged fields, a slice of such structs, or a
+// map, which becomes a single row with one column per key, sorted by key.
+func toRows(v interface{}) (Rows, error) {
+	switch r := v.(type) {
+	case Rows:
+		return r, nil
+	case *Rows:
+		return *r, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return Rows{}, nil
+		}
+
+		elemType := elemStructType(rv.Type().Elem())
+		if elemType == nil {
+			return Rows{}, fmt.Errorf("printer: cannot render %T as a table", v)
+		}
+
+		rows := Rows{Headers: structHeaders(elemType)}
+		for i := 0; i < rv.Len(); i++ {
+			rows.Rows = append(rows.Rows, structRow(indirect(rv.Index(i)), len(rows.Headers)))
+		}
+		return rows, nil
+	case reflect.Struct:
+		rows := Rows{Headers: structHeaders(rv.Type())}
+		rows.Rows = [][]string{structRow(rv, len(rows.Headers))}
+		return rows, nil
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		rows := Rows{Rows: [][]string{make([]string, 0, len(keys))}}
+		for _, k := range keys {
+			rows.Headers = append(rows.Headers, fmt.Sprint(k))
+			rows.Rows[0] = append(rows.Rows[0], fmt.Sprintf("%v", rv.MapIndex(k).Interface()))
+		}
+		return rows, nil
+	default:
+		return Rows{}, fmt.Errorf("printer: cannot render %T as a table", v)
+	}
+}
+
+func elemStructType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
+
+// structHeaders returns t's `header` tag values, in field-declaration order.
+// Fields without a header tag are skipped.
+func structHeaders(t reflect.Type) []string {
+	var headers []string
+	for i := 0; i < t.NumField(); i++ {
+		if h, ok := t.Field(i).Tag.Lookup("header"); ok {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+// structRow returns v's field values, in the same order structHeaders
+// walked them in.
+func structRow(v reflect.Value, n int) []string {
+	row := make([]string, 0, n)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("header"); ok {
+			row = append(row, fmt.Sprintf("%v", v.Field(i).Interface()))
+		}
+	}
+	return row
+}
+
+// printTabular renders v as a table (tab-aligned columns) or, if csvMode is
+// set, as RFC 4180 CSV.
+func printTabular(w io.Writer, v interface{}, csvMode bool) error {
+	rows, err := toRows(v)
+	if err != nil {
+		return err
+	}
+
+	if len(rows.Headers) == 0 {
+		return nil
+	}
+
+	if csvMode {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(rows.Headers); err != nil {
+			return err
+		}
+		for _, row := range rows.Rows {
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, joinTab(rows.Headers))
+	for _, row := range rows.Rows {
+		fmt.Fprintln(tw, joinTab(row))
+	}
+	return tw.Flush()
+}
+
+func joinTab(cols []string) string {
+	var s string
+	for i, c := range cols {
+		if i > 0 {
+			s += "\t"
+		}
+		s += c
+	}
+	return s
+}
+
+// printableDatabaseBranch is the table/json/yaml/csv representation of a
+// planetscale.DatabaseBranch, used by "branch get" and "branch list".
+type printableDatabaseBranch struct {
+	Name         string `header:"name" json:"name"`
+	ParentBranch string `header:"parent branch" json:"parent_branch"`
+	Production   bool   `header:"production" json:"production"`
+	Ready        bool   `header:"ready" json:"ready"`
+	CreatedAt    string `header:"created at" json:"created_at"`
+}
+
+// NewDatabaseBranchPrinter returns the printable representation of a single
+// database branch, for PrintResource.
+func NewDatabaseBranchPrinter(b *ps.DatabaseBranch) *printableDatabaseBranch {
+	return &printableDatabaseBranch{
+		Name:         b.Name,
+		ParentBranch: b.ParentBranch,
+		Production:   b.Production,
+		Ready:        b.Ready,
+		CreatedAt:    b.CreatedAt.String(),
+	}
+}
+
+// NewDatabaseBranchSlicePrinter returns the printable representation of a
+// list of database branches, for PrintResource.
+func NewDatabaseBranchSlicePrinter(branches []*ps.DatabaseBranch) []*printableDatabaseBranch {
+	printable := make([]*printableDatabaseBranch, 0, len(branches))
+	for _, b := range branches {
+		printable = append(printable, NewDatabaseBranchPrinter(b))
+	}
+	return printable
+}
+
+// printableDatabaseBranchStatus is the table/json/yaml/csv representation of
+// a planetscale.DatabaseBranchStatus, used by "branch status".
+type printableDatabaseBranchStatus struct {
+	Ready bool   `header:"ready" json:"ready"`
+	User  string `header:"user" json:"user"`
+}
+
+// NewDatabaseBranchStatusPrinter returns the printable representation of a
+// database branch's status, for PrintResource.
+func NewDatabaseBranchStatusPrinter(s *ps.DatabaseBranchStatus) *printableDatabaseBranchStatus {
+	return &printableDatabaseBranchStatus{
+		Ready: s.Ready,
+		User:  s.Credentials.User,
+	}
+}
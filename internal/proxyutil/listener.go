@@ -0,0 +1,177 @@
+package proxyutil
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"os"
+)
+
+// Mode selects how a LocalListener accepts incoming connections.
+type Mode string
+
+const (
+	// TCP binds a loopback TCP port, same as the historical behavior of
+	// shell.ShellCmd. This is the default mode.
+	TCP Mode = "tcp"
+	// Unix binds a Unix domain socket with 0600 permissions, for tools
+	// that prefer connecting over a filesystem path instead of a port.
+	Unix Mode = "unix"
+	// TLS binds a loopback TCP port and terminates TLS on it using a
+	// user-supplied certificate, for clients that require an encrypted
+	// connection (e.g. "mysql --ssl-mode=REQUIRED").
+	TLS Mode = "tls"
+)
+
+// ListenerOptions configures a LocalListener.
+type ListenerOptions struct {
+	Mode Mode
+
+	// TCPAddr is the address to bind for Mode TCP and Mode TLS, e.g.
+	// "127.0.0.1:0" for a random port.
+	TCPAddr string
+
+	// SocketPath is the Unix domain socket path to bind for Mode Unix.
+	SocketPath string
+
+	// CertFile and KeyFile are the TLS certificate and key to terminate
+	// TLS with for Mode TLS.
+	CertFile, KeyFile string
+}
+
+// LocalListener is a net.Listener for one of the supported local proxy
+// endpoint modes, along with a human-readable description of how to reach
+// it.
+type LocalListener struct {
+	net.Listener
+	// Addr is the plain, connectable address of the listener: a
+	// "host:port" pair for Mode TCP and Mode TLS, or a filesystem path for
+	// Mode Unix.
+	Addr string
+	// Endpoint is a human-readable description of how to reach the
+	// listener, e.g. "127.0.0.1:3306", "unix:///tmp/pscale.sock", or
+	// "127.0.0.1:3306 (tls)".
+	Endpoint string
+	Mode     Mode
+}
+
+// Listen creates a LocalListener for the given options.
+func Listen(opts ListenerOptions) (*LocalListener, error) {
+	switch opts.Mode {
+	case Unix:
+		return listenUnix(opts.SocketPath)
+	case TLS:
+		return listenTLS(opts.TCPAddr, opts.CertFile, opts.KeyFile)
+	case "", TCP:
+		return listenTCP(opts.TCPAddr)
+	default:
+		return nil, fmt.Errorf("unknown listener mode: %s", opts.Mode)
+	}
+}
+
+func listenTCP(addr string) (*LocalListener, error) {
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalListener{Listener: ln, Addr: ln.Addr().String(), Endpoint: ln.Addr().String(), Mode: TCP}, nil
+}
+
+func listenUnix(path string) (*LocalListener, error) {
+	if path == "" {
+		return nil, fmt.Errorf("socket path is required for unix listener mode")
+	}
+
+	// remove a stale socket left behind by a previous, uncleanly stopped run.
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(path)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+
+	return &LocalListener{Listener: ln, Addr: path, Endpoint: fmt.Sprintf("unix://%s", path), Mode: Unix}, nil
+}
+
+func listenTLS(addr, certFile, keyFile string) (*LocalListener, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both a cert and key file are required for tls listener mode")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load TLS cert/key: %s", err)
+	}
+
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	ln, err := tls.Listen("tcp", addr, &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalListener{Listener: ln, Addr: ln.Addr().String(), Endpoint: fmt.Sprintf("%s (tls)", ln.Addr().String()), Mode: TLS}, nil
+}
+
+// Bridge accepts connections on ln and pipes each one to a new TCP
+// connection to upstream, until ctx is canceled or ln is closed. It's used
+// to expose the sql-proxy's TCP endpoint over a LocalListener bound to a
+// Unix socket or a TLS-terminated port, since sql-proxy itself only binds
+// plain loopback TCP.
+func Bridge(ctx context.Context, ln net.Listener, upstream string) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return err
+			}
+		}
+
+		go bridgeConn(conn, upstream)
+	}
+}
+
+func bridgeConn(conn net.Conn, upstream string) {
+	defer conn.Close()
+
+	up, err := net.Dial("tcp", upstream)
+	if err != nil {
+		return
+	}
+	defer up.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(up, conn) // nolint:errcheck
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, up) // nolint:errcheck
+		done <- struct{}{}
+	}()
+	<-done
+}